@@ -0,0 +1,219 @@
+package biscuit
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flynn/biscuit-go/sig"
+)
+
+// AmbientFacts carries the per-request ambient state a BatchVerifier adds to
+// each token's Verifier before calling Verify: the resource/operation being
+// accessed, the time, and any extra facts beyond those.
+type AmbientFacts struct {
+	Resource  string
+	Operation string
+	Time      time.Time
+	Facts     []Fact
+}
+
+// BatchItem is a single token submitted to a BatchVerifier's input channel.
+type BatchItem struct {
+	Serialized []byte
+	Ambient    AmbientFacts
+}
+
+// BatchResult is emitted on a BatchVerifier's output channel for each
+// BatchItem received. Index is the item's position in the input stream, so
+// callers that need ordered results can buffer by Index.
+type BatchResult struct {
+	Index int
+	Err   error
+}
+
+// Cache lets a BatchVerifier skip re-verifying tokens it has already seen.
+// BatchVerifier computes the key itself: a sha256 over the serialized token
+// and the ambient facts (see batchCacheKey), so implementations only store
+// and look up whatever keys they are given.
+//
+// Get and Set are called concurrently from every worker in the pool, so
+// implementations must be safe for concurrent use, e.g. by guarding a map
+// with a mutex or using sync.Map.
+type Cache interface {
+	Get(key [32]byte) (ok bool, err error)
+	Set(key [32]byte, err error)
+}
+
+// Policy adds the standing facts, rules and caveats shared by every token a
+// BatchVerifier checks, before the per-request ambient facts are added.
+type Policy func(Verifier)
+
+// BatchVerifier verifies a stream of serialized tokens against a shared
+// policy using a bounded pool of workers, so the elliptic-curve signature
+// checks in Unmarshal and the datalog evaluation in Verify run concurrently
+// across tokens instead of serializing an HTTP middleware's request fan-out.
+type BatchVerifier struct {
+	// PublicKey is the root key used to verify each token's signature.
+	PublicKey sig.PublicKey
+	// Policy injects the shared standing facts/rules/caveats into every
+	// token's Verifier. May be nil.
+	Policy Policy
+	// MaxWorkers bounds the number of tokens verified concurrently. Values
+	// <= 1 verify one token at a time.
+	MaxWorkers int
+	// Cache, if set, is consulted before verifying a token and updated
+	// with the outcome afterwards.
+	Cache Cache
+	// OnVerified, if set, is called after each item is verified with the
+	// total time spent on it (cache lookup, Unmarshal, signature check and
+	// Verify together) and the resulting error. The stages are not timed
+	// separately.
+	OnVerified func(index int, took time.Duration, err error)
+}
+
+type indexedBatchItem struct {
+	index int
+	BatchItem
+}
+
+// Run verifies every item received on in, returning a channel that emits one
+// BatchResult per item, in the order workers finish them (not necessarily
+// the order items were received — use BatchResult.Index to reorder).
+// The returned channel is closed once in is closed and every in-flight
+// worker has finished. Cancelling ctx stops workers from picking up new
+// items and from blocking on a send to the returned channel, so callers that
+// stop draining it early (e.g. to bail on the first error) won't leak
+// workers; items already being verified still run to completion.
+func (bv *BatchVerifier) Run(ctx context.Context, in <-chan BatchItem) <-chan BatchResult {
+	maxWorkers := bv.MaxWorkers
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+
+	out := make(chan BatchResult)
+	indexed := make(chan indexedBatchItem)
+
+	var wg sync.WaitGroup
+	for i := 0; i < maxWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range indexed {
+				select {
+				case out <- bv.verify(item):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(indexed)
+		index := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case indexed <- indexedBatchItem{index: index, BatchItem: item}:
+					index++
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+func (bv *BatchVerifier) verify(item indexedBatchItem) BatchResult {
+	start := time.Now()
+
+	key := batchCacheKey(item.Serialized, item.Ambient)
+	if bv.Cache != nil {
+		if ok, err := bv.Cache.Get(key); ok {
+			if bv.OnVerified != nil {
+				bv.OnVerified(item.index, time.Since(start), err)
+			}
+			return BatchResult{Index: item.index, Err: err}
+		}
+	}
+
+	err := bv.verifyToken(item.Serialized, item.Ambient)
+
+	if bv.Cache != nil {
+		bv.Cache.Set(key, err)
+	}
+	if bv.OnVerified != nil {
+		bv.OnVerified(item.index, time.Since(start), err)
+	}
+
+	return BatchResult{Index: item.index, Err: err}
+}
+
+func (bv *BatchVerifier) verifyToken(serialized []byte, ambient AmbientFacts) error {
+	b, err := Unmarshal(serialized)
+	if err != nil {
+		return err
+	}
+
+	v, err := b.Verify(bv.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	if bv.Policy != nil {
+		bv.Policy(v)
+	}
+
+	if ambient.Resource != "" {
+		v.AddResource(ambient.Resource)
+	}
+	if ambient.Operation != "" {
+		v.AddOperation(ambient.Operation)
+	}
+	if !ambient.Time.IsZero() {
+		v.SetTime(ambient.Time)
+	}
+	for _, fact := range ambient.Facts {
+		v.AddFact(fact)
+	}
+
+	return v.Verify()
+}
+
+// batchCacheKey hashes a token together with the ambient facts it would be
+// verified against, so a Cache can tell apart two requests presenting the
+// same token for different resources/operations/times/facts. Every Atom
+// argument of every fact is hashed by its concrete type and value, not just
+// the predicate name, so e.g. user(alice) and user(bob) don't collide.
+func batchCacheKey(serialized []byte, ambient AmbientFacts) [32]byte {
+	h := sha256.New()
+	h.Write(serialized)
+	fmt.Fprintf(h, "|%s|%s|%d", ambient.Resource, ambient.Operation, ambient.Time.UnixNano())
+	for _, fact := range ambient.Facts {
+		fmt.Fprintf(h, "|%s(", fact.Predicate.Name)
+		for _, id := range fact.Predicate.IDs {
+			fmt.Fprintf(h, "%T:%v,", id, id)
+		}
+		h.Write([]byte(")"))
+	}
+
+	var key [32]byte
+	copy(key[:], h.Sum(nil))
+	return key
+}
@@ -0,0 +1,209 @@
+package biscuit
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flynn/biscuit-go/sig"
+	"github.com/stretchr/testify/require"
+)
+
+// newSerializedToken builds and serializes a minimal biscuit signed by root,
+// carrying a single authority fact. If failingCaveat is true, an authority
+// caveat that can never succeed is added so the token fails verification.
+func newSerializedToken(t *testing.T, root sig.Keypair, failingCaveat bool) []byte {
+	rng := rand.Reader
+	builder := NewBuilder(rng, root)
+
+	require.NoError(t, builder.AddAuthorityFact(Fact{Predicate: Predicate{
+		Name: "right",
+		IDs:  []Atom{Symbol("authority"), String("file1")},
+	}}))
+
+	if failingCaveat {
+		require.NoError(t, builder.AddAuthorityCaveat(Rule{
+			Head: Predicate{Name: "caveat_fail", IDs: []Atom{Variable("x")}},
+			Body: []Predicate{
+				{Name: "nonexistent_fact", IDs: []Atom{Variable("x")}},
+			},
+		}))
+	}
+
+	token, err := builder.Build()
+	require.NoError(t, err)
+
+	serialized, err := token.Serialize()
+	require.NoError(t, err)
+
+	return serialized
+}
+
+// TestBatchVerifierHappyPath verifies a batch of valid tokens against a
+// shared policy and expects every result to come back without error.
+func TestBatchVerifierHappyPath(t *testing.T) {
+	rng := rand.Reader
+	root := sig.GenerateKeypair(rng)
+
+	const n = 8
+	in := make(chan BatchItem, n)
+	for i := 0; i < n; i++ {
+		in <- BatchItem{
+			Serialized: newSerializedToken(t, root, false),
+			Ambient:    AmbientFacts{Resource: "file1", Operation: "read"},
+		}
+	}
+	close(in)
+
+	bv := &BatchVerifier{PublicKey: root.Public(), MaxWorkers: 3}
+	out := bv.Run(context.Background(), in)
+
+	results := make([]BatchResult, 0, n)
+	for r := range out {
+		results = append(results, r)
+	}
+
+	require.Len(t, results, n)
+	for _, r := range results {
+		require.NoError(t, r.Err)
+	}
+}
+
+// TestBatchVerifierFailingToken checks that a token failing its caveats
+// surfaces that failure on BatchResult.Err instead of being swallowed.
+func TestBatchVerifierFailingToken(t *testing.T) {
+	rng := rand.Reader
+	root := sig.GenerateKeypair(rng)
+
+	in := make(chan BatchItem, 1)
+	in <- BatchItem{Serialized: newSerializedToken(t, root, true)}
+	close(in)
+
+	bv := &BatchVerifier{PublicKey: root.Public()}
+	out := bv.Run(context.Background(), in)
+
+	result := <-out
+	require.Error(t, result.Err)
+
+	_, open := <-out
+	require.False(t, open)
+}
+
+// stubCache is a minimal, concurrency-safe Cache used to test BatchVerifier's
+// caching behavior.
+type stubCache struct {
+	mu    sync.Mutex
+	store map[[32]byte]error
+}
+
+func newStubCache() *stubCache {
+	return &stubCache{store: make(map[[32]byte]error)}
+}
+
+func (c *stubCache) Get(key [32]byte) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err, ok := c.store[key]
+	return ok, err
+}
+
+func (c *stubCache) Set(key [32]byte, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store[key] = err
+}
+
+// TestBatchVerifierCacheHitSkipsVerify checks that a Cache hit short-circuits
+// verifyToken entirely: the serialized bytes here aren't a valid biscuit, so
+// if verifyToken ran it would fail to Unmarshal and Err would be non-nil.
+// Getting a nil Err back proves the cached outcome was returned unchecked.
+func TestBatchVerifierCacheHitSkipsVerify(t *testing.T) {
+	rng := rand.Reader
+	root := sig.GenerateKeypair(rng)
+
+	item := BatchItem{
+		Serialized: []byte("not a valid biscuit token"),
+		Ambient:    AmbientFacts{Resource: "file1"},
+	}
+
+	cache := newStubCache()
+	cache.Set(batchCacheKey(item.Serialized, item.Ambient), nil)
+
+	in := make(chan BatchItem, 1)
+	in <- item
+	close(in)
+
+	bv := &BatchVerifier{PublicKey: root.Public(), Cache: cache}
+	out := bv.Run(context.Background(), in)
+
+	result := <-out
+	require.NoError(t, result.Err)
+}
+
+// TestBatchVerifierPreservesIndex checks that every item gets exactly one
+// result tagged with its original position in the input stream, so callers
+// can reorder results even though workers finish out of order.
+func TestBatchVerifierPreservesIndex(t *testing.T) {
+	rng := rand.Reader
+	root := sig.GenerateKeypair(rng)
+
+	const n = 30
+	in := make(chan BatchItem, n)
+	for i := 0; i < n; i++ {
+		in <- BatchItem{Serialized: newSerializedToken(t, root, false)}
+	}
+	close(in)
+
+	bv := &BatchVerifier{PublicKey: root.Public(), MaxWorkers: 8}
+	out := bv.Run(context.Background(), in)
+
+	seen := make([]bool, n)
+	for r := range out {
+		require.NoError(t, r.Err)
+		require.False(t, seen[r.Index], "duplicate result for index %d", r.Index)
+		seen[r.Index] = true
+	}
+	for i, ok := range seen {
+		require.True(t, ok, "missing result for index %d", i)
+	}
+}
+
+// TestBatchVerifierContextCancellationStopsNewWork checks that cancelling
+// ctx before a batch finishes keeps Run from processing every queued item,
+// and that the output channel still closes instead of hanging.
+func TestBatchVerifierContextCancellationStopsNewWork(t *testing.T) {
+	rng := rand.Reader
+	root := sig.GenerateKeypair(rng)
+
+	const n = 100
+	in := make(chan BatchItem, n)
+	for i := 0; i < n; i++ {
+		in <- BatchItem{Serialized: newSerializedToken(t, root, false)}
+	}
+	close(in)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	bv := &BatchVerifier{PublicKey: root.Public(), MaxWorkers: 2}
+	out := bv.Run(ctx, in)
+
+	results := make([]BatchResult, 0, n)
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case r, ok := <-out:
+			if !ok {
+				done = true
+				break
+			}
+			results = append(results, r)
+		case <-timeout:
+			t.Fatal("timed out waiting for out to close after ctx cancellation")
+		}
+	}
+
+	require.Less(t, len(results), n, "cancellation should stop the batch before it processes every item")
+}
@@ -1,9 +1,11 @@
 package biscuit
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/flynn/biscuit-go/datalog"
@@ -21,12 +23,26 @@ type Verifier interface {
 	AddRule(rule Rule)
 	AddCaveat(caveat Caveat)
 	Verify() error
+	VerifyParallel(ctx context.Context, maxWorkers int) error
 	Query(rule Rule) (FactSet, error)
 	BlockIndexByFactName(name string) (int, error)
 	Reset()
+	Checkpoint() VerifierCheckpoint
+	Rollback(checkpoint VerifierCheckpoint)
 	PrintWorld() string
 }
 
+// VerifierCheckpoint is an opaque snapshot of a Verifier's world, symbols and
+// caveats, taken with Checkpoint and restored with Rollback. It lets callers
+// add long-lived policy facts/rules once, checkpoint, then cheaply undo
+// whatever a single verification added on top before moving to the next one,
+// instead of paying for Reset plus re-adding the policy every time.
+type VerifierCheckpoint struct {
+	world   *datalog.World
+	symbols *datalog.SymbolTable
+	caveats []Caveat
+}
+
 type verifier struct {
 	biscuit     *Biscuit
 	baseWorld   *datalog.World
@@ -34,24 +50,44 @@ type verifier struct {
 	world       *datalog.World
 	symbols     *datalog.SymbolTable
 	caveats     []Caveat
+	maxWorkers  int
 }
 
 var _ Verifier = (*verifier)(nil)
 
-func NewVerifier(b *Biscuit) (Verifier, error) {
+// VerifierOption configures a Verifier at construction time.
+type VerifierOption func(*verifier)
+
+// WithMaxWorkers makes Verify() evaluate caveats concurrently across up to
+// maxWorkers workers instead of sequentially. A maxWorkers <= 1 keeps the
+// default sequential behavior. See VerifyParallel for the underlying
+// concurrency contract.
+func WithMaxWorkers(maxWorkers int) VerifierOption {
+	return func(v *verifier) {
+		v.maxWorkers = maxWorkers
+	}
+}
+
+func NewVerifier(b *Biscuit, opts ...VerifierOption) (Verifier, error) {
 	baseWorld, err := b.generateWorld(b.symbols)
 	if err != nil {
 		return nil, err
 	}
 
-	return &verifier{
+	v := &verifier{
 		biscuit:     b,
 		baseWorld:   baseWorld,
 		baseSymbols: b.symbols.Clone(),
 		world:       baseWorld.Clone(),
 		symbols:     b.symbols.Clone(),
 		caveats:     []Caveat{},
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v, nil
 }
 
 func (v *verifier) AddResource(res string) {
@@ -105,9 +141,39 @@ func (v *verifier) AddCaveat(caveat Caveat) {
 	v.caveats = append(v.caveats, caveat)
 }
 
+// caveatCheck is a single caveat queued for evaluation, along with the label
+// used to build its failure message.
+type caveatCheck struct {
+	label  string
+	caveat datalog.Caveat
+}
+
+// caveatChecks returns every authorizer and block caveat to evaluate, in the
+// same order Verify historically reported them in.
+func (v *verifier) caveatChecks() []caveatCheck {
+	checks := make([]caveatCheck, 0, len(v.caveats))
+	for i, caveat := range v.caveats {
+		checks = append(checks, caveatCheck{
+			label:  fmt.Sprintf("failed to verify caveat #%d", i),
+			caveat: caveat.convert(v.symbols),
+		})
+	}
+
+	for bi, blockCaveats := range v.biscuit.Caveats() {
+		for ci, caveat := range blockCaveats {
+			checks = append(checks, caveatCheck{
+				label:  fmt.Sprintf("failed to verify block #%d caveat #%d", bi, ci),
+				caveat: caveat,
+			})
+		}
+	}
+
+	return checks
+}
+
 func (v *verifier) Verify() error {
-	debug := datalog.SymbolDebugger{
-		SymbolTable: v.symbols,
+	if v.maxWorkers > 1 {
+		return v.VerifyParallel(context.Background(), v.maxWorkers)
 	}
 
 	if v.symbols.Sym("authority") == nil || v.symbols.Sym("ambient") == nil {
@@ -118,12 +184,14 @@ func (v *verifier) Verify() error {
 		return err
 	}
 
-	var errs []error
+	debug := datalog.SymbolDebugger{
+		SymbolTable: v.symbols,
+	}
 
-	for i, caveat := range v.caveats {
-		c := caveat.convert(v.symbols)
+	var errs []error
+	for _, check := range v.caveatChecks() {
 		successful := false
-		for _, query := range c.Queries {
+		for _, query := range check.caveat.Queries {
 			res := v.world.QueryRule(query)
 			if len(*res) != 0 {
 				successful = true
@@ -131,31 +199,101 @@ func (v *verifier) Verify() error {
 			}
 		}
 		if !successful {
-			errs = append(errs, fmt.Errorf("failed to verify caveat #%d: %s", i, debug.Caveat(c)))
+			errs = append(errs, fmt.Errorf("%s: %s", check.label, debug.Caveat(check.caveat)))
 		}
 	}
 
-	for bi, blockCaveats := range v.biscuit.Caveats() {
-		for ci, caveat := range blockCaveats {
-			successful := false
-			for _, query := range caveat.Queries {
-				res := v.world.QueryRule(query)
-				if len(*res) != 0 {
-					successful = true
-					break
+	if len(errs) > 0 {
+		errMsg := make([]string, len(errs))
+		for i, e := range errs {
+			errMsg[i] = e.Error()
+		}
+		return fmt.Errorf("biscuit: verification failed: %s", strings.Join(errMsg, ", "))
+	}
+
+	return nil
+}
+
+// VerifyParallel behaves like Verify but evaluates caveats concurrently
+// through a bounded pool of maxWorkers goroutines. world.Run() is executed
+// once up front on the shared world; each worker then queries its own
+// datalog.World clone, since World is only safe to read concurrently after
+// Run has completed and must not be mutated while queried. Results are
+// reassembled in the original caveat order so failure messages stay
+// reproducible. The context can be used to cancel a long-running
+// verification; a cancellation is reported as the context's error.
+func (v *verifier) VerifyParallel(ctx context.Context, maxWorkers int) error {
+	if v.symbols.Sym("authority") == nil || v.symbols.Sym("ambient") == nil {
+		return ErrMissingSymbols
+	}
+
+	if err := v.world.Run(); err != nil {
+		return err
+	}
+
+	debug := datalog.SymbolDebugger{
+		SymbolTable: v.symbols,
+	}
+	checks := v.caveatChecks()
+
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+	if maxWorkers > len(checks) {
+		// Each worker eagerly clones the world on startup, so never spin up
+		// more workers than there are caveats to check.
+		maxWorkers = len(checks)
+	}
+
+	indexes := make(chan int)
+	errs := make([]error, len(checks))
+
+	var wg sync.WaitGroup
+	for i := 0; i < maxWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			world := v.world.Clone()
+			for idx := range indexes {
+				check := checks[idx]
+				successful := false
+				for _, query := range check.caveat.Queries {
+					res := world.QueryRule(query)
+					if len(*res) != 0 {
+						successful = true
+						break
+					}
+				}
+				if !successful {
+					errs[idx] = fmt.Errorf("%s: %s", check.label, debug.Caveat(check.caveat))
 				}
 			}
-			if !successful {
-				errs = append(errs, fmt.Errorf("failed to verify block #%d caveat #%d: %s", bi, ci, debug.Caveat(caveat)))
-			}
+		}()
+	}
+
+feed:
+	for i := range checks {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
 		}
 	}
+	close(indexes)
+	wg.Wait()
 
-	if len(errs) > 0 {
-		errMsg := make([]string, len(errs))
-		for i, e := range errs {
-			errMsg[i] = e.Error()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var errMsg []string
+	for _, err := range errs {
+		if err != nil {
+			errMsg = append(errMsg, err.Error())
 		}
+	}
+
+	if len(errMsg) > 0 {
 		return fmt.Errorf("biscuit: verification failed: %s", strings.Join(errMsg, ", "))
 	}
 
@@ -216,3 +354,32 @@ func (v *verifier) Reset() {
 	v.world = v.baseWorld.Clone()
 	v.symbols = v.baseSymbols.Clone()
 }
+
+// Checkpoint captures the verifier's current world, symbols and caveats so
+// it can later be restored with Rollback. Unlike Reset, which always goes
+// back to the biscuit's base state, a checkpoint can be taken after adding
+// standing policy facts/rules/caveats, so per-request Rollback only pays for
+// re-cloning the checkpointed state rather than rebuilding the policy.
+//
+// Checkpoint/Rollback cost is proportional to the size of the world at
+// checkpoint time (the biscuit's base facts plus the policy), not to what a
+// single request adds on top. Reset + re-add instead clones the base world
+// and then re-runs every policy AddFact/AddRule/AddCaveat call on every
+// request. Rollback is a full clone of the checkpointed world; it is not an
+// O(added-facts) restore.
+func (v *verifier) Checkpoint() VerifierCheckpoint {
+	return VerifierCheckpoint{
+		world:   v.world.Clone(),
+		symbols: v.symbols.Clone(),
+		caveats: append([]Caveat{}, v.caveats...),
+	}
+}
+
+// Rollback restores the verifier to the state captured by checkpoint,
+// discarding any facts, rules or caveats added since. See Checkpoint for the
+// cost this is expected to have relative to Reset.
+func (v *verifier) Rollback(checkpoint VerifierCheckpoint) {
+	v.world = checkpoint.world.Clone()
+	v.symbols = checkpoint.symbols.Clone()
+	v.caveats = append([]Caveat{}, checkpoint.caveats...)
+}
@@ -1,6 +1,7 @@
 package biscuit
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
 	"testing"
@@ -59,3 +60,194 @@ func TestGetBlockIndexForFactName(t *testing.T) {
 	_, err = v.BlockIndexByFactName("block_2_fact_1")
 	require.Error(t, err)
 }
+
+// addPassingCaveat adds an authorizer caveat that always succeeds as long as
+// a resource ambient fact has been set, e.g. via Verifier.AddResource.
+func addPassingCaveat(v Verifier) {
+	v.AddCaveat(Caveat{Queries: []Rule{{
+		Head: Predicate{Name: "caveat_pass", IDs: []Atom{Variable("res")}},
+		Body: []Predicate{
+			{Name: "resource", IDs: []Atom{Symbol("ambient"), Variable("res")}},
+		},
+	}}})
+}
+
+// addFailingCaveat adds an authorizer caveat that can never succeed, since
+// nothing ever produces a "nonexistent_fact" fact.
+func addFailingCaveat(v Verifier) {
+	v.AddCaveat(Caveat{Queries: []Rule{{
+		Head: Predicate{Name: "caveat_fail", IDs: []Atom{Variable("x")}},
+		Body: []Predicate{
+			{Name: "nonexistent_fact", IDs: []Atom{Variable("x")}},
+		},
+	}}})
+}
+
+// newCaveatVerifier builds a fresh verifier over an empty biscuit with a mix
+// of passing and failing authorizer caveats, so both Verify and
+// VerifyParallel have multiple successes and multiple failures to aggregate.
+func newCaveatVerifier(t *testing.T) Verifier {
+	rng := rand.Reader
+	root := sig.GenerateKeypair(rng)
+	token, err := NewBuilder(rng, root).Build()
+	require.NoError(t, err)
+
+	v, err := NewVerifier(token)
+	require.NoError(t, err)
+
+	v.AddResource("file1")
+	for i := 0; i < 3; i++ {
+		addPassingCaveat(v)
+	}
+	for i := 0; i < 2; i++ {
+		addFailingCaveat(v)
+	}
+
+	return v
+}
+
+// TestVerifyParallelMatchesSequential proves that evaluating caveats through
+// the bounded worker pool produces the same verdict, and the same aggregated
+// error string, as the sequential path. The two only agree if
+// datalog.World.Clone/QueryRule are safe to use concurrently once Run has
+// completed, which is the assumption VerifyParallel's whole design rests on.
+func TestVerifyParallelMatchesSequential(t *testing.T) {
+	seqErr := newCaveatVerifier(t).Verify()
+	require.Error(t, seqErr)
+
+	parErr := newCaveatVerifier(t).VerifyParallel(context.Background(), 4)
+	require.Error(t, parErr)
+
+	require.Equal(t, seqErr.Error(), parErr.Error())
+}
+
+// TestVerifyParallelMatchesSequentialNoFailures is the mirror case: every
+// caveat passes, so both paths must report success.
+func TestVerifyParallelMatchesSequentialNoFailures(t *testing.T) {
+	rng := rand.Reader
+	root := sig.GenerateKeypair(rng)
+	token, err := NewBuilder(rng, root).Build()
+	require.NoError(t, err)
+
+	newPassingVerifier := func() Verifier {
+		v, err := NewVerifier(token)
+		require.NoError(t, err)
+		v.AddResource("file1")
+		for i := 0; i < 5; i++ {
+			addPassingCaveat(v)
+		}
+		return v
+	}
+
+	require.NoError(t, newPassingVerifier().Verify())
+	require.NoError(t, newPassingVerifier().VerifyParallel(context.Background(), 4))
+}
+
+// TestWithMaxWorkersMatchesVerifyParallel checks that routing through
+// Verify() via the WithMaxWorkers option produces the same result as calling
+// VerifyParallel directly.
+func TestWithMaxWorkersMatchesVerifyParallel(t *testing.T) {
+	rng := rand.Reader
+	root := sig.GenerateKeypair(rng)
+	token, err := NewBuilder(rng, root).Build()
+	require.NoError(t, err)
+
+	direct, err := NewVerifier(token)
+	require.NoError(t, err)
+	direct.AddResource("file1")
+	for i := 0; i < 3; i++ {
+		addPassingCaveat(direct)
+	}
+	for i := 0; i < 2; i++ {
+		addFailingCaveat(direct)
+	}
+	directErr := direct.VerifyParallel(context.Background(), 4)
+	require.Error(t, directErr)
+
+	viaOption, err := NewVerifier(token, WithMaxWorkers(4))
+	require.NoError(t, err)
+	viaOption.AddResource("file1")
+	for i := 0; i < 3; i++ {
+		addPassingCaveat(viaOption)
+	}
+	for i := 0; i < 2; i++ {
+		addFailingCaveat(viaOption)
+	}
+	optionErr := viaOption.Verify()
+	require.Error(t, optionErr)
+
+	require.Equal(t, directErr.Error(), optionErr.Error())
+}
+
+// TestVerifyParallelCancellation checks that a cancelled context is reported
+// as-is, rather than an aggregated caveat failure message or a nil error,
+// even though every caveat would pass.
+func TestVerifyParallelCancellation(t *testing.T) {
+	rng := rand.Reader
+	root := sig.GenerateKeypair(rng)
+	token, err := NewBuilder(rng, root).Build()
+	require.NoError(t, err)
+
+	v, err := NewVerifier(token)
+	require.NoError(t, err)
+	v.AddResource("file1")
+	for i := 0; i < 200; i++ {
+		addPassingCaveat(v)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = v.VerifyParallel(ctx, 2)
+	require.Equal(t, context.Canceled, err)
+}
+
+// BenchmarkCheckpointVsReset compares restoring a verifier to a checkpoint
+// taken after loading a few hundred standing policy facts against doing a
+// full Reset and re-adding that same policy on every iteration. The policy
+// is added to the verifier itself (not the biscuit's authority block), so
+// Reset actually has to pay for re-adding it every time, the cost Checkpoint
+// is meant to avoid.
+func BenchmarkCheckpointVsReset(b *testing.B) {
+	rng := rand.Reader
+	root := sig.GenerateKeypair(rng)
+	builder := NewBuilder(rng, root)
+
+	token, err := builder.Build()
+	require.NoError(b, err)
+
+	const policyFacts = 300
+	addPolicy := func(v Verifier) {
+		for i := 0; i < policyFacts; i++ {
+			v.AddFact(Fact{Predicate: Predicate{
+				Name: fmt.Sprintf("policy_fact_%d", i),
+				IDs:  []Atom{Integer(i)},
+			}})
+		}
+	}
+
+	b.Run("Reset", func(b *testing.B) {
+		v, err := token.Verify(root.Public())
+		require.NoError(b, err)
+
+		for i := 0; i < b.N; i++ {
+			v.Reset()
+			addPolicy(v)
+			v.AddResource("file1")
+			v.AddOperation("read")
+		}
+	})
+
+	b.Run("Checkpoint", func(b *testing.B) {
+		v, err := token.Verify(root.Public())
+		require.NoError(b, err)
+		addPolicy(v)
+		checkpoint := v.Checkpoint()
+
+		for i := 0; i < b.N; i++ {
+			v.AddResource("file1")
+			v.AddOperation("read")
+			v.Rollback(checkpoint)
+		}
+	})
+}